@@ -0,0 +1,20 @@
+package mojo
+
+import "context"
+
+// Client is the set of operations *Mojo exposes against the Mojo API.
+// Extracted so callers can depend on an interface instead of *Mojo,
+// letting tests substitute mojotest.Fake instead of spinning up an
+// httptest.Server.
+type Client interface {
+	AddContact(ctx context.Context, contacts ...Contact) error
+	AddNote(ctx context.Context, contactID string, note string) error
+	GetContact(ctx context.Context, id string) (Contact, error)
+	UpdateContact(ctx context.Context, c Contact) error
+	DeleteContact(ctx context.Context, id string) error
+	ListContacts(ctx context.Context, opts ListOpts) (ContactPage, error)
+	ListGroups(ctx context.Context) ([]Group, error)
+	ListNotes(ctx context.Context, contactID string) ([]Note, error)
+}
+
+var _ Client = (*Mojo)(nil)
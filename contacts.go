@@ -0,0 +1,110 @@
+package mojo
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// resourcePath builds the path for a Mojo resource, e.g.
+// resourcePath("contacts", "") -> "/api/contacts/" and
+// resourcePath("contacts", "42") -> "/api/contacts/42/".
+func resourcePath(resource, id string) string {
+	if id == "" {
+		return fmt.Sprintf("/api/%s/", resource)
+	}
+	return fmt.Sprintf("/api/%s/%s/", resource, id)
+}
+
+// GetContact fetches a single Contact by ID.
+//
+// Returns ErrNotFound if no contact with that ID exists.
+func (mj *Mojo) GetContact(ctx context.Context, id string) (Contact, error) {
+	var c Contact
+	err := mj.do(ctx, "GET", resourcePath("contacts", id), nil, &c)
+	return c, err
+}
+
+// UpdateContact replaces an existing Contact in Mojo. Contact ID and
+// GroupID must be provided, same as AddContact.
+//
+// Returns ErrNotFound if no contact with that ID exists.
+func (mj *Mojo) UpdateContact(ctx context.Context, c Contact) error {
+	return mj.do(ctx, "PUT", resourcePath("contacts", c.ID), c, nil)
+}
+
+// DeleteContact removes a Contact from Mojo.
+//
+// Returns ErrNotFound if no contact with that ID exists.
+func (mj *Mojo) DeleteContact(ctx context.Context, id string) error {
+	return mj.do(ctx, "DELETE", resourcePath("contacts", id), nil, nil)
+}
+
+// ListOpts narrows down and paginates ListContacts.
+type ListOpts struct {
+	// GroupID, if non-zero, restricts the listing to contacts in that group.
+	GroupID int
+
+	// Cursor resumes a previous listing from ContactPage.NextCursor. Leave
+	// empty to start from the first page.
+	Cursor string
+}
+
+// ContactPage is one page of results from ListContacts.
+type ContactPage struct {
+	Contacts   []Contact
+	NextCursor string
+}
+
+// ListContacts lists contacts, optionally filtered by group and paginated
+// through ContactPage.NextCursor.
+func (mj *Mojo) ListContacts(ctx context.Context, opts ListOpts) (ContactPage, error) {
+	q := url.Values{}
+	if opts.GroupID != 0 {
+		q.Set("group_id", fmt.Sprintf("%d", opts.GroupID))
+	}
+	if opts.Cursor != "" {
+		q.Set("cursor", opts.Cursor)
+	}
+	path := resourcePath("contacts", "")
+	if encoded := q.Encode(); encoded != "" {
+		path += "?" + encoded
+	}
+	var page struct {
+		Results []Contact `json:"results"`
+		Next    string    `json:"next"`
+	}
+	if err := mj.do(ctx, "GET", path, nil, &page); err != nil {
+		return ContactPage{}, err
+	}
+	return ContactPage{Contacts: page.Results, NextCursor: page.Next}, nil
+}
+
+// Group is a Mojo contact group, used to partition contacts (e.g. for lead
+// routing) and required when creating a Contact.
+type Group struct {
+	ID   int    `json:"group_id"`
+	Name string `json:"name"`
+}
+
+// ListGroups lists every group in the account.
+func (mj *Mojo) ListGroups(ctx context.Context) ([]Group, error) {
+	var groups []Group
+	err := mj.do(ctx, "GET", resourcePath("groups", ""), nil, &groups)
+	return groups, err
+}
+
+// Note is a note attached to a contact, as returned by ListNotes.
+type Note struct {
+	ID        int    `json:"note_id"`
+	ContactID string `json:"api_contact_id"`
+	Contents  string `json:"contents"`
+}
+
+// ListNotes lists the notes attached to a contact, most recent first.
+func (mj *Mojo) ListNotes(ctx context.Context, contactID string) ([]Note, error) {
+	q := url.Values{"api_contact_id": {contactID}}
+	var notes []Note
+	err := mj.do(ctx, "GET", resourcePath("notes", "")+"?"+q.Encode(), nil, &notes)
+	return notes, err
+}
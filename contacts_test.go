@@ -0,0 +1,135 @@
+package mojo_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/RealGeeks/mojo-go"
+)
+
+func TestMojo_GetContact(t *testing.T) {
+	var path string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		io.WriteString(w, `{"errors": [], "result": {
+			"api_contact_id": "654A4BFB-41B6-4058-B91E-879ECE2C5A0A",
+			"full_name": "Jason Polakow",
+			"contactgroup_set": [{"group_id": 2}],
+			"mediainfo_set": [{"type": 4, "value": "jason@jp-australia.com"}]
+		}}`)
+	}))
+	defer ts.Close()
+
+	client := &mojo.Mojo{URL: ts.URL, Token: "token"}
+	c, err := client.GetContact(context.Background(), "654A4BFB-41B6-4058-B91E-879ECE2C5A0A")
+
+	ok(t, err)
+	equals(t, "/api/contacts/654A4BFB-41B6-4058-B91E-879ECE2C5A0A/", path)
+	equals(t, mojo.Contact{
+		ID:      "654A4BFB-41B6-4058-B91E-879ECE2C5A0A",
+		GroupID: 2,
+		Name:    "Jason Polakow",
+		Email:   "jason@jp-australia.com",
+	}, c)
+}
+
+func TestMojo_GetContact_NotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+		io.WriteString(w, `{"detail": "Not found."}`)
+	}))
+	defer ts.Close()
+
+	client := &mojo.Mojo{URL: ts.URL, Token: "token"}
+	_, err := client.GetContact(context.Background(), "missing")
+
+	assert(t, err != nil, "should return error")
+	_, ok := err.(*mojo.ErrNotFound)
+	assert(t, ok, "expected *mojo.ErrNotFound, got %T", err)
+}
+
+func TestMojo_UpdateContact(t *testing.T) {
+	var method, path string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		io.WriteString(w, `{"errors": [], "result": {}}`)
+	}))
+	defer ts.Close()
+
+	client := &mojo.Mojo{URL: ts.URL, Token: "token"}
+	err := client.UpdateContact(context.Background(), mojo.Contact{ID: "1", GroupID: 2, Name: "Bob"})
+
+	ok(t, err)
+	equals(t, "PUT", method)
+	equals(t, "/api/contacts/1/", path)
+}
+
+func TestMojo_DeleteContact(t *testing.T) {
+	var method, path string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		io.WriteString(w, `{"errors": [], "result": null}`)
+	}))
+	defer ts.Close()
+
+	client := &mojo.Mojo{URL: ts.URL, Token: "token"}
+	err := client.DeleteContact(context.Background(), "1")
+
+	ok(t, err)
+	equals(t, "DELETE", method)
+	equals(t, "/api/contacts/1/", path)
+}
+
+func TestMojo_ListContacts(t *testing.T) {
+	var query string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		io.WriteString(w, `{"errors": [], "result": {
+			"results": [{"api_contact_id": "1", "full_name": "Bob", "contactgroup_set": [{"group_id": 2}]}],
+			"next": "cursor-2"
+		}}`)
+	}))
+	defer ts.Close()
+
+	client := &mojo.Mojo{URL: ts.URL, Token: "token"}
+	page, err := client.ListContacts(context.Background(), mojo.ListOpts{GroupID: 2, Cursor: "cursor-1"})
+
+	ok(t, err)
+	equals(t, "cursor=cursor-1&group_id=2", query)
+	equals(t, mojo.ContactPage{
+		Contacts:   []mojo.Contact{{ID: "1", GroupID: 2, Name: "Bob"}},
+		NextCursor: "cursor-2",
+	}, page)
+}
+
+func TestMojo_ListGroups(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"errors": [], "result": [{"group_id": 1, "name": "Buyers"}, {"group_id": 2, "name": "Sellers"}]}`)
+	}))
+	defer ts.Close()
+
+	client := &mojo.Mojo{URL: ts.URL, Token: "token"}
+	groups, err := client.ListGroups(context.Background())
+
+	ok(t, err)
+	equals(t, []mojo.Group{{ID: 1, Name: "Buyers"}, {ID: 2, Name: "Sellers"}}, groups)
+}
+
+func TestMojo_ListNotes(t *testing.T) {
+	var query string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		io.WriteString(w, `{"errors": [], "result": [{"note_id": 1, "api_contact_id": "1", "contents": "called him today"}]}`)
+	}))
+	defer ts.Close()
+
+	client := &mojo.Mojo{URL: ts.URL, Token: "token"}
+	notes, err := client.ListNotes(context.Background(), "1")
+
+	ok(t, err)
+	equals(t, "api_contact_id=1", query)
+	equals(t, []mojo.Note{{ID: 1, ContactID: "1", Contents: "called him today"}}, notes)
+}
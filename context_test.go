@@ -0,0 +1,54 @@
+package mojo_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/RealGeeks/mojo-go"
+)
+
+func TestMojo_AddContact_ContextCancelled(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"duplicated_api_contact_id": [], "errors": [], "result": []}`)
+	}))
+	defer ts.Close()
+
+	client := &mojo.Mojo{URL: ts.URL, Token: "token"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := client.AddContact(ctx, mojo.Contact{ID: "1", GroupID: 2, Name: "Bob"})
+
+	assert(t, err != nil, "should return error when context is already cancelled")
+}
+
+// recordingDoer implements mojo.Doer to prove *Mojo accepts any Doer, not
+// just *http.Client.
+type recordingDoer struct {
+	http.Client
+	calls int
+}
+
+func (d *recordingDoer) Do(req *http.Request) (*http.Response, error) {
+	d.calls++
+	return d.Client.Do(req)
+}
+
+func TestMojo_AddContact_CustomDoer(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"duplicated_api_contact_id": [], "errors": [], "result": []}`)
+	}))
+	defer ts.Close()
+
+	doer := &recordingDoer{Client: http.Client{Timeout: time.Second}}
+	client := &mojo.Mojo{URL: ts.URL, Token: "token", HTTP: doer}
+
+	err := client.AddContact(context.Background(), mojo.Contact{ID: "1", GroupID: 2, Name: "Bob"})
+
+	ok(t, err)
+	equals(t, 1, doer.calls)
+}
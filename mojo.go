@@ -3,9 +3,11 @@ package mojo
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"strings"
@@ -42,6 +44,35 @@ func (e *ErrForbidden) Error() string {
 	return fmt.Sprintf("mojo: %v", e.Msg)
 }
 
+// ErrNotFound is returned on status code 404, usually because the resource
+// id passed to GetContact, UpdateContact or DeleteContact does not exist
+type ErrNotFound struct {
+	Msg string
+}
+
+func (e *ErrNotFound) Error() string {
+	return fmt.Sprintf("mojo: %v", e.Msg)
+}
+
+// ErrLocked is returned when Mojo keeps responding that the previous
+// request was not finished or was interrupted, even after RetryPolicy's
+// attempts have been exhausted.
+type ErrLocked struct {
+	Msg string
+}
+
+func (e *ErrLocked) Error() string {
+	return fmt.Sprintf("mojo: %v", e.Msg)
+}
+
+// Doer performs an HTTP request, the same interface implemented by
+// *http.Client. Implement it to plug in instrumented transports
+// (OpenTelemetry, retry middleware, request signers) without wrapping the
+// whole client.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
 // Mojo client
 type Mojo struct {
 	// URL for this account, including protocol + host, example:
@@ -51,23 +82,45 @@ type Mojo struct {
 	URL string
 
 	// Token is the access token provided by Mojo after the client
-	// has logged in using OAuth
+	// has logged in using OAuth. It is a shortcut for setting
+	// TokenSource to a source that always returns this value; if
+	// TokenSource is set, Token is ignored.
 	Token string
 
-	HTTP *http.Client // (optional) http client to perform requests
+	// TokenSource supplies the bearer token used to authenticate
+	// requests, and is asked to refresh it if Mojo responds with 403.
+	// If nil, Token is used instead.
+	TokenSource TokenSource
+
+	// RetryPolicy controls how requests retry transient failures: network
+	// timeouts, 502/503/504 responses and Mojo's "previous request not
+	// finished" lock error. The zero value uses sensible defaults.
+	RetryPolicy RetryPolicy
+
+	// HTTP performs the requests, defaulting to an *http.Client with a
+	// 3 second timeout when nil. Set a deadline on the context passed to
+	// each call instead of relying on this timeout when one is needed.
+	HTTP Doer
+}
+
+func (mj *Mojo) tokenSource() TokenSource {
+	if mj.TokenSource != nil {
+		return mj.TokenSource
+	}
+	return staticTokenSource(mj.Token)
 }
 
 // AddNote adds a note to an existing contact
 //
 // Return ErrInvalid on validation errors and ErrForbidden if token is invalid
-func (mj *Mojo) AddNote(contactID string, note string) error {
+func (mj *Mojo) AddNote(ctx context.Context, contactID string, note string) error {
 	data := map[string]interface{}{"api_contact_id": contactID, "contents": note, "type": 1}
 	reqbody, err := json.Marshal(data)
 	if err != nil {
 		return &ErrInvalid{Msg: err.Error()}
 	}
 	url := prefixHTTP(mj.URL) + "/api/notes/"
-	resbody, err := mj.post(url, reqbody)
+	resbody, err := mj.request(ctx, "POST", url, reqbody)
 	if err != nil {
 		return err
 	}
@@ -98,22 +151,32 @@ func (err nonFieldErr) all() string  { return strings.Join(err.Errors, ", ") }
 //
 // Return ErrDuplicate if a contact with same ID already exists. Return other errors
 // if can't make the request of if Mojo returns an error
-func (mj *Mojo) AddContact(contacts ...Contact) error {
-	reqbody, err := json.Marshal(contacts)
-	if err != nil {
-		return &ErrInvalid{Msg: err.Error()}
+func (mj *Mojo) AddContact(ctx context.Context, contacts ...Contact) error {
+	return mj.do(ctx, "POST", "/api/contacts/bulk_create/", contacts, nil)
+}
+
+// do issues a request to path, marshaling body (if not nil) as the JSON
+// request body, decoding the response's Mojo envelope
+// ({"result": ..., "errors": ..., "duplicated_api_contact_id": ...}) and
+// unmarshaling its "result" into out (if not nil). It shares auth, retry
+// and error handling with every other endpoint through request.
+func (mj *Mojo) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reqbody []byte
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return &ErrInvalid{Msg: err.Error()}
+		}
+		reqbody = b
 	}
-	url := prefixHTTP(mj.URL) + "/api/contacts/bulk_create/"
-	resbody, err := mj.post(url, reqbody)
+	url := prefixHTTP(mj.URL) + path
+	resbody, err := mj.request(ctx, method, url, reqbody)
 	if err != nil {
 		return err
 	}
 	var data mojoResponse
 	if err := json.Unmarshal(resbody, &data); err != nil {
-		return fmt.Errorf("mojo: POST %s %s decoding %s (%v)", url, string(reqbody), string(resbody), err)
-	}
-	if data.isLockedError() {
-		return fmt.Errorf("mojo: %s", data.errorMsg())
+		return fmt.Errorf("mojo: %s %s %s decoding %s (%v)", method, url, string(reqbody), string(resbody), err)
 	}
 	if data.isDuplicate() {
 		return &ErrDuplicate{IDs: data.duplicatedIDs()}
@@ -121,38 +184,109 @@ func (mj *Mojo) AddContact(contacts ...Contact) error {
 	if data.isError() {
 		return &ErrInvalid{Msg: data.errorMsg()}
 	}
+	if out != nil && len(data.Result) > 0 {
+		if err := json.Unmarshal(data.Result, out); err != nil {
+			return fmt.Errorf("mojo: %s %s decoding result %s (%v)", method, url, string(data.Result), err)
+		}
+	}
 	return nil
 }
 
-func (mj *Mojo) post(url string, reqbody []byte) (resbody []byte, err error) {
-	req, err := http.NewRequest("POST", url, bytes.NewReader(reqbody))
+func (mj *Mojo) request(ctx context.Context, method, url string, reqbody []byte) (resbody []byte, err error) {
+	ts := mj.tokenSource()
+	token, err := ts.Token(ctx)
+	if err != nil {
+		return []byte{}, fmt.Errorf("mojo: %s %s %s fail to get token (%v)", method, url, string(reqbody), err)
+	}
+
+	policy := mj.retryPolicy()
+	for attempt := 1; ; attempt++ {
+		resbody, status, httpErr := mj.doRequest(ctx, method, url, reqbody, token)
+		if httpErr != nil {
+			if attempt < policy.MaxAttempts && isTransientErr(httpErr) {
+				if werr := policy.wait(ctx, attempt); werr != nil {
+					return []byte{}, werr
+				}
+				continue
+			}
+			return []byte{}, httpErr
+		}
+
+		if status == 403 {
+			token, err = ts.Refresh(ctx)
+			if err != nil {
+				return []byte{}, fmt.Errorf("mojo: token refresh failed: %w", err)
+			}
+			resbody, status, httpErr = mj.doRequest(ctx, method, url, reqbody, token)
+			if httpErr != nil {
+				return []byte{}, httpErr
+			}
+			if status == 403 {
+				return []byte{}, newForbidden(resbody)
+			}
+		}
+		if status == 400 {
+			return []byte{}, &ErrInvalid{Msg: fmt.Sprintf("%s %s %s %d validation error %s", method, url, string(reqbody), status, string(resbody))}
+		}
+		if status == 404 {
+			return []byte{}, &ErrNotFound{Msg: fmt.Sprintf("%s %s not found: %s", method, url, string(resbody))}
+		}
+		if isTransientStatus(status) {
+			if attempt < policy.MaxAttempts {
+				if werr := policy.wait(ctx, attempt); werr != nil {
+					return []byte{}, werr
+				}
+				continue
+			}
+			return []byte{}, fmt.Errorf("mojo: %s %s %s status %d with body %v", method, url, string(reqbody), status, string(resbody))
+		}
+		if status != 200 {
+			return []byte{}, fmt.Errorf("mojo: %s %s %s status %d with body %v", method, url, string(reqbody), status, string(resbody))
+		}
+
+		var data mojoResponse
+		if json.Unmarshal(resbody, &data) == nil && data.isLockedError() {
+			if attempt < policy.MaxAttempts {
+				if werr := policy.wait(ctx, attempt); werr != nil {
+					return []byte{}, werr
+				}
+				continue
+			}
+			return []byte{}, &ErrLocked{Msg: data.errorMsg()}
+		}
+		return resbody, nil
+	}
+}
+
+// doRequest issues a single HTTP request with the given bearer token,
+// returning the response body and status code. HTTP-layer failures (can't
+// build the request, transport error, can't read the body) are returned as
+// err; non-2xx status codes are returned as status with err == nil so the
+// caller can decide how to interpret them (e.g. retry a 403 once).
+func (mj *Mojo) doRequest(ctx context.Context, method, url string, reqbody []byte, token string) (resbody []byte, status int, err error) {
+	var body io.Reader
+	if len(reqbody) > 0 {
+		body = bytes.NewReader(reqbody)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
-		return []byte{}, fmt.Errorf("mojo: POST %s %s fail to build request (%v)", url, string(reqbody), err)
+		return []byte{}, 0, fmt.Errorf("mojo: %s %s %s fail to build request (%v)", method, url, string(reqbody), err)
 	}
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+mj.Token)
+	req.Header.Set("Authorization", "Bearer "+token)
 	if mj.HTTP == nil {
 		mj.HTTP = &http.Client{Timeout: 3 * time.Second}
 	}
 	res, err := mj.HTTP.Do(req)
 	if err != nil {
-		return []byte{}, fmt.Errorf("mojo: POST %s %s fail (%v)", url, string(reqbody), err)
+		return []byte{}, 0, fmt.Errorf("mojo: %s %s %s fail (%w)", method, url, string(reqbody), err)
 	}
 	defer res.Body.Close()
 	resbody, err = ioutil.ReadAll(res.Body)
 	if err != nil {
-		return []byte{}, fmt.Errorf("mojo: POST %s %s fail to read response (%v)", url, string(reqbody), err)
-	}
-	if res.StatusCode == 403 {
-		return []byte{}, newForbidden(resbody)
+		return []byte{}, 0, fmt.Errorf("mojo: %s %s %s fail to read response (%v)", method, url, string(reqbody), err)
 	}
-	if res.StatusCode == 400 {
-		return []byte{}, &ErrInvalid{Msg: fmt.Sprintf("POST %s %s %d validation error %s", url, string(reqbody), res.StatusCode, string(resbody))}
-	}
-	if res.StatusCode != 200 {
-		return []byte{}, fmt.Errorf("mojo: POST %s %s status %d with body %v", url, string(reqbody), res.StatusCode, string(resbody))
-	}
-	return resbody, nil
+	return resbody, res.StatusCode, nil
 }
 
 func newForbidden(body []byte) error {
@@ -169,8 +303,9 @@ func newForbidden(body []byte) error {
 }
 
 type mojoResponse struct {
-	Errors                 []string `json:"errors"`
-	DuplicatedAPIContactID []string `json:"duplicated_api_contact_id"`
+	Result                 json.RawMessage `json:"result"`
+	Errors                 []string        `json:"errors"`
+	DuplicatedAPIContactID []string        `json:"duplicated_api_contact_id"`
 }
 
 func (resp mojoResponse) isError() bool {
@@ -244,6 +379,43 @@ func (c Contact) MarshalJSON() ([]byte, error) {
 	return data, err
 }
 
+// UnmarshalJSON decodes a Contact from the same representation Mojo
+// returns from GetContact and ListContacts, reversing MarshalJSON's mapping
+// of Email/MobilePhone/WorkPhone/HomePhone into mediainfo_set.
+func (c *Contact) UnmarshalJSON(data []byte) error {
+	var cc contact
+	if err := json.Unmarshal(data, &cc); err != nil {
+		return err
+	}
+	*c = Contact{
+		ID:      cc.ID,
+		Name:    cc.Name,
+		Address: cc.Address,
+		City:    cc.City,
+		State:   cc.State,
+		Zip:     cc.Zip,
+	}
+	if len(cc.Group) > 0 {
+		c.GroupID = cc.Group[0]["group_id"]
+	}
+	for _, m := range cc.Media {
+		switch m.Type {
+		case 1:
+			c.WorkPhone = m.Value
+		case 2:
+			c.MobilePhone = m.Value
+		case 3:
+			c.HomePhone = m.Value
+		case 4:
+			c.Email = m.Value
+		}
+	}
+	for _, nt := range cc.Notes {
+		c.Notes = append(c.Notes, nt.Contents)
+	}
+	return nil
+}
+
 func cleanPhone(ph string) string {
 	ph = strings.Replace(ph, "(", "", -1)
 	ph = strings.Replace(ph, ")", "", -1)
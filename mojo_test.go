@@ -1,6 +1,7 @@
 package mojo_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -112,7 +113,7 @@ func TestMojo_AddContact(t *testing.T) {
 		URL:   ts.URL,
 		Token: "5cf3edd8ccc78ea750abdcb9367fb072",
 	}
-	err := client.AddContact(mojo.Contact{
+	err := client.AddContact(context.Background(), mojo.Contact{
 		ID:      "654A4BFB-41B6-4058-B91E-879ECE2C5A0A",
 		GroupID: 2,
 		Name:    "Jason Polakow",
@@ -154,7 +155,7 @@ func TestMojo_AddContactMultiple(t *testing.T) {
 		GroupID: 3,
 		Name:    "Amanda",
 	}
-	err := client.AddContact(c1, c2)
+	err := client.AddContact(context.Background(), c1, c2)
 
 	ok(t, err)
 	equals(t, []map[string]interface{}{
@@ -200,7 +201,7 @@ func TestMojo_AddContact_Duplicate(t *testing.T) {
 		URL:   ts.URL,
 		Token: "5cf3edd8ccc78ea750abdcb9367fb072",
 	}
-	err := client.AddContact(
+	err := client.AddContact(context.Background(),
 		mojo.Contact{ID: "a030a3fae0aa57f6bebf368fc4370221", GroupID: 2, Name: "Bob"},
 		mojo.Contact{ID: "68d480032155501eb2b2ca4c6a053306", GroupID: 2, Name: "Ana"},
 	)
@@ -218,7 +219,7 @@ func TestMojo_AddContact_MissingGroup(t *testing.T) {
 		URL:   ts.URL,
 		Token: "5cf3edd8ccc78ea750abdcb9367fb072",
 	}
-	err := client.AddContact(mojo.Contact{
+	err := client.AddContact(context.Background(), mojo.Contact{
 		ID:      "654A4BFB-41B6-4058-B91E-879ECE2C5A0A",
 		GroupID: 2,
 		Name:    "Jason Polakow",
@@ -242,7 +243,7 @@ func TestMojo_AddContact_PreviousRequestUnfinished(t *testing.T) {
 		URL:   ts.URL,
 		Token: "5cf3edd8ccc78ea750abdcb9367fb072",
 	}
-	err := client.AddContact(mojo.Contact{
+	err := client.AddContact(context.Background(), mojo.Contact{
 		ID:      "654A4BFB-41B6-4058-B91E-879ECE2C5A0A",
 		GroupID: 2,
 		Name:    "Jason Polakow",
@@ -263,14 +264,17 @@ func TestMojo_AddContact_InvalidStatusCode(t *testing.T) {
 		URL:   ts.URL,
 		Token: "5cf3edd8ccc78ea750abdcb9367fb072",
 	}
-	err := client.AddContact(mojo.Contact{
+	err := client.AddContact(context.Background(), mojo.Contact{
 		ID:      "654A4BFB-41B6-4058-B91E-879ECE2C5A0A",
 		GroupID: 2,
 		Name:    "Jason Polakow",
 	})
 
 	assert(t, err != nil, "should return error")
-	equals(t, "mojo: invalid status code 500 with body opssss", err.Error())
+	equals(t, fmt.Sprintf(
+		`mojo: POST %s/api/contacts/bulk_create/ [{"api_contact_id":"654A4BFB-41B6-4058-B91E-879ECE2C5A0A","full_name":"Jason Polakow","contactgroup_set":[{"group_id":2}]}] status 500 with body opssss`,
+		ts.URL,
+	), err.Error())
 }
 
 func TestMojo_AddContact_Forbidden(t *testing.T) {
@@ -284,7 +288,7 @@ func TestMojo_AddContact_Forbidden(t *testing.T) {
 		URL:   ts.URL,
 		Token: "invalid",
 	}
-	err := client.AddContact(mojo.Contact{
+	err := client.AddContact(context.Background(), mojo.Contact{
 		ID:      "654A4BFB-41B6-4058-B91E-879ECE2C5A0A",
 		GroupID: 2,
 		Name:    "Jason Polakow",
@@ -304,7 +308,7 @@ func TestMojo_AddContact_ForbiddenNotJSON(t *testing.T) {
 		URL:   ts.URL,
 		Token: "invalid",
 	}
-	err := client.AddContact(mojo.Contact{
+	err := client.AddContact(context.Background(), mojo.Contact{
 		ID:      "654A4BFB-41B6-4058-B91E-879ECE2C5A0A",
 		GroupID: 2,
 		Name:    "Jason Polakow",
@@ -324,7 +328,7 @@ func TestMojo_AddContact_ForbiddenUnknownBody(t *testing.T) {
 		URL:   ts.URL,
 		Token: "invalid",
 	}
-	err := client.AddContact(mojo.Contact{
+	err := client.AddContact(context.Background(), mojo.Contact{
 		ID:      "654A4BFB-41B6-4058-B91E-879ECE2C5A0A",
 		GroupID: 2,
 		Name:    "Jason Polakow",
@@ -343,14 +347,17 @@ func TestMojo_AddContact_InvalidJSONResponse(t *testing.T) {
 		URL:   ts.URL,
 		Token: "5cf3edd8ccc78ea750abdcb9367fb072",
 	}
-	err := client.AddContact(mojo.Contact{
+	err := client.AddContact(context.Background(), mojo.Contact{
 		ID:      "654A4BFB-41B6-4058-B91E-879ECE2C5A0A",
 		GroupID: 2,
 		Name:    "Jason Polakow",
 	})
 
 	assert(t, err != nil, "should return error")
-	equals(t, "mojo: decoding response body (invalid character 'o' looking for beginning of value)", err.Error())
+	equals(t, fmt.Sprintf(
+		`mojo: POST %s/api/contacts/bulk_create/ [{"api_contact_id":"654A4BFB-41B6-4058-B91E-879ECE2C5A0A","full_name":"Jason Polakow","contactgroup_set":[{"group_id":2}]}] decoding ops (invalid character 'o' looking for beginning of value)`,
+		ts.URL,
+	), err.Error())
 }
 
 func readBody(t *testing.T, r *http.Request) (body []map[string]interface{}) {
@@ -0,0 +1,214 @@
+// Package mojotest provides an in-memory fake of mojo.Client, so callers
+// can unit test code that talks to Mojo without spinning up an
+// httptest.Server.
+package mojotest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/RealGeeks/mojo-go"
+)
+
+// Fake is an in-memory mojo.Client. It stores contacts and notes in memory,
+// enforces the same validation AddContact/UpdateContact do (required
+// ID/GroupID, duplicate detection by ID, at least one of Name/Email/phone),
+// and can be configured to fail the next call with FailNext,
+// SimulateLocked or SimulateForbidden.
+type Fake struct {
+	mu       sync.Mutex
+	contacts map[string]mojo.Contact
+	notes    map[string][]mojo.Note
+	nextNote int
+	failNext error
+}
+
+// NewFake returns an empty Fake ready to use.
+func NewFake() *Fake {
+	return &Fake{
+		contacts: make(map[string]mojo.Contact),
+		notes:    make(map[string][]mojo.Note),
+	}
+}
+
+var _ mojo.Client = (*Fake)(nil)
+
+// FailNext makes the next call to any Fake method return err instead of
+// performing its normal operation.
+func (f *Fake) FailNext(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failNext = err
+}
+
+// SimulateLocked makes the next call fail with mojo.ErrLocked, as Mojo does
+// when a previous request has not finished.
+func (f *Fake) SimulateLocked() {
+	f.FailNext(&mojo.ErrLocked{Msg: "Previous request was not finished or was interrupted."})
+}
+
+// SimulateForbidden makes the next call fail with mojo.ErrForbidden, as Mojo
+// does when the access token is invalid.
+func (f *Fake) SimulateForbidden() {
+	f.FailNext(&mojo.ErrForbidden{Msg: "Invalid access_token"})
+}
+
+// takeErr returns and clears the error queued by FailNext, if any. Caller
+// must hold f.mu.
+func (f *Fake) takeErr() error {
+	err := f.failNext
+	f.failNext = nil
+	return err
+}
+
+func validateContact(c mojo.Contact) error {
+	if c.ID == "" {
+		return &mojo.ErrInvalid{Msg: "missing required field ID"}
+	}
+	if c.GroupID == 0 {
+		return &mojo.ErrInvalid{Msg: "missing required field GroupID"}
+	}
+	if c.Name == "" && c.Email == "" && c.MobilePhone == "" && c.WorkPhone == "" && c.HomePhone == "" {
+		return &mojo.ErrInvalid{Msg: "at least one of Name, Email, MobilePhone, WorkPhone or HomePhone is required"}
+	}
+	return nil
+}
+
+// AddContact implements mojo.Client.
+func (f *Fake) AddContact(ctx context.Context, contacts ...mojo.Contact) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.takeErr(); err != nil {
+		return err
+	}
+	var dupIDs []string
+	for _, c := range contacts {
+		if err := validateContact(c); err != nil {
+			return err
+		}
+		if _, exists := f.contacts[c.ID]; exists {
+			dupIDs = append(dupIDs, c.ID)
+		}
+	}
+	if len(dupIDs) > 0 {
+		return &mojo.ErrDuplicate{IDs: dupIDs}
+	}
+	for _, c := range contacts {
+		f.contacts[c.ID] = c
+	}
+	return nil
+}
+
+// AddNote implements mojo.Client.
+func (f *Fake) AddNote(ctx context.Context, contactID string, note string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.takeErr(); err != nil {
+		return err
+	}
+	if _, exists := f.contacts[contactID]; !exists {
+		return &mojo.ErrInvalid{Msg: "Invalid api_contact_id."}
+	}
+	f.nextNote++
+	f.notes[contactID] = append(f.notes[contactID], mojo.Note{
+		ID:        f.nextNote,
+		ContactID: contactID,
+		Contents:  note,
+	})
+	return nil
+}
+
+// GetContact implements mojo.Client.
+func (f *Fake) GetContact(ctx context.Context, id string) (mojo.Contact, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.takeErr(); err != nil {
+		return mojo.Contact{}, err
+	}
+	c, ok := f.contacts[id]
+	if !ok {
+		return mojo.Contact{}, &mojo.ErrNotFound{Msg: fmt.Sprintf("contact %s not found", id)}
+	}
+	return c, nil
+}
+
+// UpdateContact implements mojo.Client.
+func (f *Fake) UpdateContact(ctx context.Context, c mojo.Contact) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.takeErr(); err != nil {
+		return err
+	}
+	if err := validateContact(c); err != nil {
+		return err
+	}
+	if _, exists := f.contacts[c.ID]; !exists {
+		return &mojo.ErrNotFound{Msg: fmt.Sprintf("contact %s not found", c.ID)}
+	}
+	f.contacts[c.ID] = c
+	return nil
+}
+
+// DeleteContact implements mojo.Client.
+func (f *Fake) DeleteContact(ctx context.Context, id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.takeErr(); err != nil {
+		return err
+	}
+	if _, exists := f.contacts[id]; !exists {
+		return &mojo.ErrNotFound{Msg: fmt.Sprintf("contact %s not found", id)}
+	}
+	delete(f.contacts, id)
+	delete(f.notes, id)
+	return nil
+}
+
+// ListContacts implements mojo.Client. It ignores opts.Cursor: the fake
+// always returns every matching contact in a single page.
+func (f *Fake) ListContacts(ctx context.Context, opts mojo.ListOpts) (mojo.ContactPage, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.takeErr(); err != nil {
+		return mojo.ContactPage{}, err
+	}
+	var page mojo.ContactPage
+	for _, c := range f.contacts {
+		if opts.GroupID != 0 && c.GroupID != opts.GroupID {
+			continue
+		}
+		page.Contacts = append(page.Contacts, c)
+	}
+	return page, nil
+}
+
+// ListGroups implements mojo.Client, returning one Group (with an empty
+// Name) per distinct GroupID seen across the stored contacts.
+func (f *Fake) ListGroups(ctx context.Context) ([]mojo.Group, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.takeErr(); err != nil {
+		return nil, err
+	}
+	seen := make(map[int]bool)
+	var groups []mojo.Group
+	for _, c := range f.contacts {
+		if seen[c.GroupID] {
+			continue
+		}
+		seen[c.GroupID] = true
+		groups = append(groups, mojo.Group{ID: c.GroupID})
+	}
+	return groups, nil
+}
+
+// ListNotes implements mojo.Client.
+func (f *Fake) ListNotes(ctx context.Context, contactID string) ([]mojo.Note, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.takeErr(); err != nil {
+		return nil, err
+	}
+	return f.notes[contactID], nil
+}
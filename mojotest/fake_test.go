@@ -0,0 +1,113 @@
+package mojotest_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/RealGeeks/mojo-go"
+	"github.com/RealGeeks/mojo-go/mojotest"
+)
+
+func TestFake_AddContactThenGetContact(t *testing.T) {
+	f := mojotest.NewFake()
+
+	err := f.AddContact(context.Background(), mojo.Contact{ID: "1", GroupID: 2, Name: "Bob"})
+	ok(t, err)
+
+	c, err := f.GetContact(context.Background(), "1")
+	ok(t, err)
+	equals(t, mojo.Contact{ID: "1", GroupID: 2, Name: "Bob"}, c)
+}
+
+func TestFake_AddContact_Duplicate(t *testing.T) {
+	f := mojotest.NewFake()
+	ctx := context.Background()
+	ok(t, f.AddContact(ctx, mojo.Contact{ID: "1", GroupID: 2, Name: "Bob"}))
+
+	err := f.AddContact(ctx, mojo.Contact{ID: "1", GroupID: 2, Name: "Bob"})
+
+	equals(t, &mojo.ErrDuplicate{IDs: []string{"1"}}, err)
+}
+
+func TestFake_AddContact_MissingGroupID(t *testing.T) {
+	f := mojotest.NewFake()
+
+	err := f.AddContact(context.Background(), mojo.Contact{ID: "1", Name: "Bob"})
+
+	equals(t, &mojo.ErrInvalid{Msg: "missing required field GroupID"}, err)
+}
+
+func TestFake_AddContact_NoIdentifyingField(t *testing.T) {
+	f := mojotest.NewFake()
+
+	err := f.AddContact(context.Background(), mojo.Contact{ID: "1", GroupID: 2})
+
+	equals(t, &mojo.ErrInvalid{Msg: "at least one of Name, Email, MobilePhone, WorkPhone or HomePhone is required"}, err)
+}
+
+func TestFake_GetContact_NotFound(t *testing.T) {
+	f := mojotest.NewFake()
+
+	_, err := f.GetContact(context.Background(), "missing")
+
+	equals(t, &mojo.ErrNotFound{Msg: "contact missing not found"}, err)
+}
+
+func TestFake_DeleteContact(t *testing.T) {
+	f := mojotest.NewFake()
+	ctx := context.Background()
+	ok(t, f.AddContact(ctx, mojo.Contact{ID: "1", GroupID: 2, Name: "Bob"}))
+
+	ok(t, f.DeleteContact(ctx, "1"))
+
+	_, err := f.GetContact(ctx, "1")
+	equals(t, &mojo.ErrNotFound{Msg: "contact 1 not found"}, err)
+}
+
+func TestFake_AddNoteThenListNotes(t *testing.T) {
+	f := mojotest.NewFake()
+	ctx := context.Background()
+	ok(t, f.AddContact(ctx, mojo.Contact{ID: "1", GroupID: 2, Name: "Bob"}))
+
+	ok(t, f.AddNote(ctx, "1", "called him today"))
+
+	notes, err := f.ListNotes(ctx, "1")
+	ok(t, err)
+	equals(t, []mojo.Note{{ID: 1, ContactID: "1", Contents: "called him today"}}, notes)
+}
+
+func TestFake_SimulateLocked(t *testing.T) {
+	f := mojotest.NewFake()
+	f.SimulateLocked()
+
+	err := f.AddContact(context.Background(), mojo.Contact{ID: "1", GroupID: 2, Name: "Bob"})
+
+	equals(t, &mojo.ErrLocked{Msg: "Previous request was not finished or was interrupted."}, err)
+
+	// the simulated failure only applies to the next call
+	ok(t, f.AddContact(context.Background(), mojo.Contact{ID: "1", GroupID: 2, Name: "Bob"}))
+}
+
+func TestFake_SimulateForbidden(t *testing.T) {
+	f := mojotest.NewFake()
+	f.SimulateForbidden()
+
+	_, err := f.GetContact(context.Background(), "1")
+
+	equals(t, &mojo.ErrForbidden{Msg: "Invalid access_token"}, err)
+}
+
+// ok fails the test if an err is not nil.
+func ok(tb testing.TB, err error) {
+	if err != nil {
+		tb.Fatalf("unexpected error: %s", err)
+	}
+}
+
+// equals fails the test if exp is not equal to act.
+func equals(tb testing.TB, exp, act interface{}) {
+	if !reflect.DeepEqual(exp, act) {
+		tb.Fatalf("exp: %#v\n\ngot: %#v", exp, act)
+	}
+}
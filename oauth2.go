@@ -0,0 +1,319 @@
+package mojo
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// TokenSource supplies the bearer token used to authenticate requests to
+// Mojo. Token returns a token that is currently (or was last known to be)
+// valid; Refresh obtains a new token to replace the current one.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+	Refresh(ctx context.Context) (string, error)
+}
+
+// staticTokenSource is the TokenSource used when a Mojo client is
+// configured with the plain Token field instead of a TokenSource, kept for
+// backwards compatibility. It never refreshes.
+type staticTokenSource string
+
+func (s staticTokenSource) Token(ctx context.Context) (string, error)   { return string(s), nil }
+func (s staticTokenSource) Refresh(ctx context.Context) (string, error) { return string(s), nil }
+
+const (
+	defaultLockInitialBackoff = 100 * time.Millisecond
+	defaultLockMaxBackoff     = 3 * time.Second
+	defaultLockMaxTotal       = 30 * time.Second
+
+	// expiryMargin is subtracted from a token's expiration so Token()
+	// refreshes a little before Mojo would actually reject it.
+	expiryMargin = 30 * time.Second
+)
+
+// OAuth2TokenSource obtains and refreshes an access token from Mojo's OAuth2
+// token endpoint using a refresh token.
+//
+// Refreshes are serialized across processes that share the same
+// credentials (e.g. multiple instances of the same service) through an
+// on-disk lock file, modeled on the lock used by cloudflared to protect
+// its own credentials file: the lock is acquired with a bounded
+// exponential backoff, and a stale lock left behind by a crashed process
+// is removed and retried once.
+type OAuth2TokenSource struct {
+	TokenURL     string // Mojo's OAuth2 token endpoint
+	ClientID     string
+	ClientSecret string
+	RefreshToken string
+
+	// LockPath is the path to the lock file used to serialize refreshes.
+	// Defaults to a file under os.UserCacheDir().
+	LockPath string
+
+	HTTP *http.Client // (optional) http client used to call TokenURL
+
+	// LockInitialBackoff, LockMaxBackoff and LockMaxTotal override the
+	// defaults (100ms, 3s, 30s) used when waiting to acquire the lock.
+	// Mainly useful for tests.
+	LockInitialBackoff time.Duration
+	LockMaxBackoff     time.Duration
+	LockMaxTotal       time.Duration
+
+	mu    sync.Mutex
+	token string
+	exp   time.Time
+}
+
+// Token returns a cached access token, refreshing it first if it is
+// missing or about to expire.
+func (s *OAuth2TokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	token, exp := s.token, s.exp
+	s.mu.Unlock()
+	if token != "" && time.Now().Before(exp) {
+		return token, nil
+	}
+	return s.Refresh(ctx)
+}
+
+// Refresh obtains a new access token from TokenURL, serializing with any
+// other process refreshing the same credentials. If another refresh stored
+// a different, unexpired token while this one waited for the lock, that
+// token is returned instead of requesting another.
+func (s *OAuth2TokenSource) Refresh(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	current := s.token
+	s.mu.Unlock()
+
+	unlock, err := s.acquireLock(ctx)
+	if err != nil {
+		return "", fmt.Errorf("mojo: oauth2 refresh (%v)", err)
+	}
+	defer unlock()
+
+	// another process may have refreshed while we waited for the lock
+	if token, exp, ok := s.readTokenFile(); ok && token != current && time.Now().Before(exp) {
+		s.mu.Lock()
+		s.token, s.exp = token, exp
+		s.mu.Unlock()
+		return token, nil
+	}
+
+	token, exp, err := s.requestToken(ctx)
+	if err != nil {
+		return "", fmt.Errorf("mojo: oauth2 refresh (%v)", err)
+	}
+	if err := s.writeTokenFile(token, exp); err != nil {
+		return "", fmt.Errorf("mojo: oauth2 refresh (%v)", err)
+	}
+	s.mu.Lock()
+	s.token, s.exp = token, exp
+	s.mu.Unlock()
+	return token, nil
+}
+
+func (s *OAuth2TokenSource) requestToken(ctx context.Context) (token string, exp time.Time, err error) {
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"client_id":     {s.ClientID},
+		"client_secret": {s.ClientSecret},
+		"refresh_token": {s.RefreshToken},
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", s.TokenURL, bytes.NewReader([]byte(form.Encode())))
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("building request (%v)", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	client := s.HTTP
+	if client == nil {
+		client = &http.Client{Timeout: 3 * time.Second}
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("requesting token (%v)", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != 200 {
+		return "", time.Time{}, fmt.Errorf("token endpoint returned status %d", res.StatusCode)
+	}
+	var data struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&data); err != nil {
+		return "", time.Time{}, fmt.Errorf("decoding token response (%v)", err)
+	}
+	return data.AccessToken, time.Now().Add(time.Duration(data.ExpiresIn) * time.Second).Add(-expiryMargin), nil
+}
+
+// tokenFile is the JSON document persisted next to the lock so other
+// processes sharing the same credentials can reuse a freshly obtained
+// token instead of requesting a new one.
+type tokenFile struct {
+	AccessToken string    `json:"access_token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+func (s *OAuth2TokenSource) tokenPath() string {
+	return s.lockPath() + ".json"
+}
+
+func (s *OAuth2TokenSource) readTokenFile() (token string, exp time.Time, ok bool) {
+	data, err := os.ReadFile(s.tokenPath())
+	if err != nil {
+		return "", time.Time{}, false
+	}
+	var tf tokenFile
+	if err := json.Unmarshal(data, &tf); err != nil {
+		return "", time.Time{}, false
+	}
+	return tf.AccessToken, tf.ExpiresAt, true
+}
+
+// writeTokenFile persists the token atomically: it writes to a temporary
+// file in the same directory and renames it over the final path, so a
+// concurrent reader never observes a partial write.
+func (s *OAuth2TokenSource) writeTokenFile(token string, exp time.Time) error {
+	path := s.tokenPath()
+	data, err := json.Marshal(tokenFile{AccessToken: token, ExpiresAt: exp})
+	if err != nil {
+		return fmt.Errorf("encoding token (%v)", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("writing token file (%v)", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("renaming token file (%v)", err)
+	}
+	return nil
+}
+
+func (s *OAuth2TokenSource) lockPath() string {
+	if s.LockPath != "" {
+		return s.LockPath
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "mojo-go", fmt.Sprintf("oauth2-%s.lock", s.credentialsHash()))
+}
+
+// credentialsHash identifies the account this OAuth2TokenSource refreshes
+// tokens for, so that a process juggling several Mojo accounts (Mojo.URL's
+// own doc comment notes "Each mojo client has their own url") gets a
+// distinct default LockPath/tokenPath per account instead of every
+// OAuth2TokenSource colliding on the same files.
+func (s *OAuth2TokenSource) credentialsHash() string {
+	sum := sha256.Sum256([]byte(s.TokenURL + "|" + s.ClientID))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// acquireLock acquires the on-disk refresh lock, returning a function that
+// releases it. While the lock is held, SIGINT/SIGTERM are intercepted so an
+// interrupted refresh still removes the lock file instead of leaving it
+// behind for the next process to wait out; the signal is then re-delivered
+// through its default handler so the process terminates as it normally
+// would.
+func (s *OAuth2TokenSource) acquireLock(ctx context.Context) (unlock func(), err error) {
+	path := s.lockPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("creating lock dir (%v)", err)
+	}
+
+	locked, err := s.waitForLock(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	if !locked {
+		// backoff exhausted: assume the lock is stale (left behind by a
+		// crashed or interrupted process) and retry once.
+		os.Remove(path)
+		locked, err = s.waitForLock(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		if !locked {
+			return nil, fmt.Errorf("could not acquire refresh lock %s", path)
+		}
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case sig := <-sigCh:
+			os.Remove(path)
+			// Re-deliver the signal through its default handler so the
+			// process still terminates: signal.Notify above suppressed
+			// Go's normal terminate-on-signal behavior for as long as the
+			// lock is held.
+			signal.Reset(sig)
+			syscall.Kill(syscall.Getpid(), sig.(syscall.Signal))
+		case <-done:
+		}
+	}()
+
+	var once sync.Once
+	unlock = func() {
+		once.Do(func() {
+			close(done)
+			signal.Stop(sigCh)
+			os.Remove(path)
+		})
+	}
+	return unlock, nil
+}
+
+func (s *OAuth2TokenSource) waitForLock(ctx context.Context, path string) (bool, error) {
+	initial, max, total := s.LockInitialBackoff, s.LockMaxBackoff, s.LockMaxTotal
+	if initial == 0 {
+		initial = defaultLockInitialBackoff
+	}
+	if max == 0 {
+		max = defaultLockMaxBackoff
+	}
+	if total == 0 {
+		total = defaultLockMaxTotal
+	}
+
+	backoff := initial
+	deadline := time.Now().Add(total)
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			f.Close()
+			return true, nil
+		}
+		if !os.IsExist(err) {
+			return false, fmt.Errorf("creating lock file (%v)", err)
+		}
+		if time.Now().After(deadline) {
+			return false, nil
+		}
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > max {
+			backoff = max
+		}
+	}
+}
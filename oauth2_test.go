@@ -0,0 +1,187 @@
+package mojo_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/RealGeeks/mojo-go"
+)
+
+func TestOAuth2TokenSource_Token_FetchesAndCaches(t *testing.T) {
+	var hits int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		io.WriteString(w, `{"access_token": "abc123", "expires_in": 3600}`)
+	}))
+	defer ts.Close()
+
+	src := &mojo.OAuth2TokenSource{
+		TokenURL:     ts.URL,
+		ClientID:     "client",
+		ClientSecret: "secret",
+		RefreshToken: "refresh",
+		LockPath:     filepath.Join(t.TempDir(), "oauth2.lock"),
+	}
+
+	tok, err := src.Token(context.Background())
+	ok(t, err)
+	equals(t, "abc123", tok)
+
+	tok, err = src.Token(context.Background())
+	ok(t, err)
+	equals(t, "abc123", tok)
+
+	equals(t, int32(1), atomic.LoadInt32(&hits))
+}
+
+func TestOAuth2TokenSource_Token_RefreshesWhenExpired(t *testing.T) {
+	var hits int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&hits, 1)
+		if n == 1 {
+			io.WriteString(w, `{"access_token": "first", "expires_in": 0}`)
+		} else {
+			io.WriteString(w, `{"access_token": "second", "expires_in": 3600}`)
+		}
+	}))
+	defer ts.Close()
+
+	src := &mojo.OAuth2TokenSource{
+		TokenURL:     ts.URL,
+		ClientID:     "client",
+		ClientSecret: "secret",
+		RefreshToken: "refresh",
+		LockPath:     filepath.Join(t.TempDir(), "oauth2.lock"),
+	}
+
+	tok, err := src.Token(context.Background())
+	ok(t, err)
+	equals(t, "first", tok)
+
+	tok, err = src.Token(context.Background())
+	ok(t, err)
+	equals(t, "second", tok)
+}
+
+func TestOAuth2TokenSource_Refresh_ConcurrentCallsShareResult(t *testing.T) {
+	var hits int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		time.Sleep(20 * time.Millisecond)
+		io.WriteString(w, `{"access_token": "shared", "expires_in": 3600}`)
+	}))
+	defer ts.Close()
+
+	src := &mojo.OAuth2TokenSource{
+		TokenURL:     ts.URL,
+		ClientID:     "client",
+		ClientSecret: "secret",
+		RefreshToken: "refresh",
+		LockPath:     filepath.Join(t.TempDir(), "oauth2.lock"),
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, 5)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = src.Refresh(context.Background())
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		ok(t, err)
+	}
+	assert(t, atomic.LoadInt32(&hits) < int32(len(errs)), "expected concurrent refreshes to be deduplicated, got %d HTTP calls", hits)
+}
+
+func TestOAuth2TokenSource_Refresh_StaleLockIsRemoved(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"access_token": "abc123", "expires_in": 3600}`)
+	}))
+	defer ts.Close()
+
+	lockPath := filepath.Join(t.TempDir(), "oauth2.lock")
+	f, err := os.Create(lockPath)
+	ok(t, err)
+	f.Close()
+
+	src := &mojo.OAuth2TokenSource{
+		TokenURL:           ts.URL,
+		ClientID:           "client",
+		ClientSecret:       "secret",
+		RefreshToken:       "refresh",
+		LockPath:           lockPath,
+		LockInitialBackoff: time.Millisecond,
+		LockMaxBackoff:     5 * time.Millisecond,
+		LockMaxTotal:       20 * time.Millisecond,
+	}
+
+	tok, err := src.Refresh(context.Background())
+	ok(t, err)
+	equals(t, "abc123", tok)
+}
+
+// Environment variables used to hand the held-lock helper process (below)
+// its configuration, since it runs as a re-exec of this same test binary.
+const (
+	envHoldLock = "MOJO_TEST_HOLD_LOCK"
+	envLockPath = "MOJO_TEST_LOCK_PATH"
+	envTokenURL = "MOJO_TEST_TOKEN_URL"
+)
+
+func TestOAuth2TokenSource_Refresh_InterruptedRefreshRemovesLock(t *testing.T) {
+	if os.Getenv(envHoldLock) != "" {
+		// We are the re-exec'd helper process: hold the refresh lock by
+		// refreshing against a slow TokenURL, until our parent signals us.
+		src := &mojo.OAuth2TokenSource{
+			TokenURL:     os.Getenv(envTokenURL),
+			ClientID:     "client",
+			ClientSecret: "secret",
+			RefreshToken: "refresh",
+			LockPath:     os.Getenv(envLockPath),
+		}
+		src.Refresh(context.Background())
+		return
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(2 * time.Second)
+		io.WriteString(w, `{"access_token": "abc123", "expires_in": 3600}`)
+	}))
+	defer ts.Close()
+
+	lockPath := filepath.Join(t.TempDir(), "oauth2.lock")
+	cmd := exec.Command(os.Args[0], "-test.run=^TestOAuth2TokenSource_Refresh_InterruptedRefreshRemovesLock$")
+	cmd.Env = append(os.Environ(), envHoldLock+"=1", envLockPath+"="+lockPath, envTokenURL+"="+ts.URL)
+	ok(t, cmd.Start())
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, err := os.Stat(lockPath); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("helper process never created the lock file")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	ok(t, cmd.Process.Signal(syscall.SIGTERM))
+	cmd.Wait() // expected to report the helper died from the signal
+
+	_, err := os.Stat(lockPath)
+	assert(t, os.IsNotExist(err), "expected lock file to be removed after interrupted refresh, stat err = %v", err)
+}
@@ -0,0 +1,94 @@
+package mojo
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"time"
+)
+
+const (
+	defaultRetryMaxAttempts  = 3
+	defaultRetryInitialDelay = 200 * time.Millisecond
+	defaultRetryMaxDelay     = 2 * time.Second
+)
+
+// RetryPolicy configures how Mojo retries a request that failed
+// transiently: a timeout or temporary network error, a 502/503/504
+// response, or Mojo's "previous request not finished" lock error. Delay
+// between attempts grows exponentially from InitialDelay, capped at
+// MaxDelay, randomized by +/- Jitter (a fraction of the delay, 0-1).
+//
+// The zero value uses sensible defaults (3 attempts, 200ms initial delay,
+// 2s max delay, no jitter).
+type RetryPolicy struct {
+	MaxAttempts  int
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+	Jitter       float64
+
+	// Sleep waits for d or until ctx is done, whichever comes first.
+	// Defaults to a context-aware time.Sleep. Exposed so tests can
+	// observe/skip the actual backoff.
+	Sleep func(ctx context.Context, d time.Duration) error
+}
+
+func (mj *Mojo) retryPolicy() RetryPolicy {
+	p := mj.RetryPolicy
+	if p.MaxAttempts == 0 {
+		p.MaxAttempts = defaultRetryMaxAttempts
+	}
+	if p.InitialDelay == 0 {
+		p.InitialDelay = defaultRetryInitialDelay
+	}
+	if p.MaxDelay == 0 {
+		p.MaxDelay = defaultRetryMaxDelay
+	}
+	if p.Sleep == nil {
+		p.Sleep = sleepContext
+	}
+	return p
+}
+
+// wait sleeps for the backoff delay of the given attempt (1-indexed).
+func (p RetryPolicy) wait(ctx context.Context, attempt int) error {
+	delay := p.InitialDelay * time.Duration(int64(1)<<uint(attempt-1))
+	if delay <= 0 || delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if p.Jitter > 0 {
+		delay += time.Duration(float64(delay) * p.Jitter * (rand.Float64()*2 - 1))
+		if delay < 0 {
+			delay = 0
+		}
+	}
+	return p.Sleep(ctx, delay)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// isTransientErr reports whether err is a network-level error worth
+// retrying: a timeout or a temporary error.
+func isTransientErr(err error) bool {
+	var nerr net.Error
+	if errors.As(err, &nerr) {
+		return nerr.Timeout() || nerr.Temporary()
+	}
+	return false
+}
+
+// isTransientStatus reports whether status is a server error worth
+// retrying.
+func isTransientStatus(status int) bool {
+	return status == 502 || status == 503 || status == 504
+}
@@ -0,0 +1,136 @@
+package mojo_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/RealGeeks/mojo-go"
+)
+
+// noSleep makes RetryPolicy skip the actual backoff while recording the
+// delay it would have waited, so tests run instantly but can still assert
+// on the number and size of attempts.
+func noSleep(delays *[]time.Duration) func(ctx context.Context, d time.Duration) error {
+	return func(ctx context.Context, d time.Duration) error {
+		*delays = append(*delays, d)
+		return nil
+	}
+}
+
+func TestMojo_AddContact_RetriesLockedError(t *testing.T) {
+	var hits int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&hits, 1)
+		if n < 3 {
+			io.WriteString(w, `{"errors": ["Previous request was not finished or was interrupted."], "result": null}`)
+			return
+		}
+		io.WriteString(w, `{"duplicated_api_contact_id": [], "errors": [], "result": []}`)
+	}))
+	defer ts.Close()
+
+	var delays []time.Duration
+	client := &mojo.Mojo{
+		URL:   ts.URL,
+		Token: "token",
+		RetryPolicy: mojo.RetryPolicy{
+			MaxAttempts:  5,
+			InitialDelay: time.Millisecond,
+			MaxDelay:     10 * time.Millisecond,
+			Sleep:        noSleep(&delays),
+		},
+	}
+	err := client.AddContact(context.Background(), mojo.Contact{ID: "1", GroupID: 2, Name: "Bob"})
+
+	ok(t, err)
+	equals(t, int32(3), atomic.LoadInt32(&hits))
+	equals(t, 2, len(delays))
+}
+
+func TestMojo_AddContact_LockedErrorExhaustsRetries(t *testing.T) {
+	var hits int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		io.WriteString(w, `{"errors": ["Previous request was not finished or was interrupted."], "result": null}`)
+	}))
+	defer ts.Close()
+
+	var delays []time.Duration
+	client := &mojo.Mojo{
+		URL:   ts.URL,
+		Token: "token",
+		RetryPolicy: mojo.RetryPolicy{
+			MaxAttempts:  3,
+			InitialDelay: time.Millisecond,
+			MaxDelay:     10 * time.Millisecond,
+			Sleep:        noSleep(&delays),
+		},
+	}
+	err := client.AddContact(context.Background(), mojo.Contact{ID: "1", GroupID: 2, Name: "Bob"})
+
+	equals(t, &mojo.ErrLocked{Msg: "Previous request was not finished or was interrupted."}, err)
+	equals(t, int32(3), atomic.LoadInt32(&hits))
+	equals(t, 2, len(delays))
+}
+
+func TestMojo_AddContact_RetriesTransientStatus(t *testing.T) {
+	var hits int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&hits, 1)
+		if n < 2 {
+			w.WriteHeader(503)
+			io.WriteString(w, `service unavailable`)
+			return
+		}
+		io.WriteString(w, `{"duplicated_api_contact_id": [], "errors": [], "result": []}`)
+	}))
+	defer ts.Close()
+
+	var delays []time.Duration
+	client := &mojo.Mojo{
+		URL:   ts.URL,
+		Token: "token",
+		RetryPolicy: mojo.RetryPolicy{
+			MaxAttempts:  3,
+			InitialDelay: time.Millisecond,
+			MaxDelay:     10 * time.Millisecond,
+			Sleep:        noSleep(&delays),
+		},
+	}
+	err := client.AddContact(context.Background(), mojo.Contact{ID: "1", GroupID: 2, Name: "Bob"})
+
+	ok(t, err)
+	equals(t, int32(2), atomic.LoadInt32(&hits))
+	equals(t, 1, len(delays))
+}
+
+func TestMojo_AddContact_DoesNotRetryInvalid(t *testing.T) {
+	var hits int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		io.WriteString(w, `{"errors": ["All contacts should have the same group_id."], "result": null}`)
+	}))
+	defer ts.Close()
+
+	var delays []time.Duration
+	client := &mojo.Mojo{
+		URL:   ts.URL,
+		Token: "token",
+		RetryPolicy: mojo.RetryPolicy{
+			MaxAttempts:  3,
+			InitialDelay: time.Millisecond,
+			MaxDelay:     10 * time.Millisecond,
+			Sleep:        noSleep(&delays),
+		},
+	}
+	err := client.AddContact(context.Background(), mojo.Contact{ID: "1", GroupID: 2, Name: "Bob"})
+
+	equals(t, &mojo.ErrInvalid{Msg: "All contacts should have the same group_id."}, err)
+	equals(t, int32(1), atomic.LoadInt32(&hits))
+	equals(t, 0, len(delays))
+}